@@ -0,0 +1,29 @@
+package safecookie
+
+import "golang.org/x/crypto/chacha20poly1305"
+
+// NewChaCha20Poly1305 returns a new SafeCookie instance backed by
+// ChaCha20-Poly1305 given a 32-byte key, using the IETF variant's 12-byte
+// nonce.
+func NewChaCha20Poly1305(key []byte) (*SafeCookie, error) {
+	aead, err := chacha20poly1305.New(key)
+	if err != nil {
+		return nil, usageError("chacha20poly1305: invalid key", err)
+	}
+
+	return &SafeCookie{AEAD: aead}, nil
+}
+
+// NewXChaCha20Poly1305 returns a new SafeCookie instance backed by
+// XChaCha20-Poly1305 given a 32-byte key. Its 24-byte nonce is large enough
+// to generate at random without the collision risk that comes with
+// ChaCha20-Poly1305's 12-byte nonce, at the cost of a slightly larger
+// ciphertext.
+func NewXChaCha20Poly1305(key []byte) (*SafeCookie, error) {
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, usageError("chacha20poly1305: invalid key", err)
+	}
+
+	return &SafeCookie{AEAD: aead}, nil
+}
@@ -0,0 +1,61 @@
+package safecookie_test
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/codahale/safecookie"
+)
+
+func TestChaCha20Poly1305RoundTrip(t *testing.T) {
+	original := []byte("this is a secret")
+
+	c := http.Cookie{
+		Name: "wingle",
+	}
+
+	sc, err := safecookie.NewChaCha20Poly1305([]byte("yellow submarine, yellow subma!!"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := sc.Seal(original, &c); err != nil {
+		t.Fatal(err)
+	}
+
+	actual, err := sc.Open(&c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(actual, original) {
+		t.Errorf("Was %x, but expected %x", actual, original)
+	}
+}
+
+func TestXChaCha20Poly1305RoundTrip(t *testing.T) {
+	original := []byte("this is a secret")
+
+	c := http.Cookie{
+		Name: "wingle",
+	}
+
+	sc, err := safecookie.NewXChaCha20Poly1305([]byte("yellow submarine, yellow subma!!"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := sc.Seal(original, &c); err != nil {
+		t.Fatal(err)
+	}
+
+	actual, err := sc.Open(&c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(actual, original) {
+		t.Errorf("Was %x, but expected %x", actual, original)
+	}
+}
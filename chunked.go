@@ -0,0 +1,157 @@
+package safecookie
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// DefaultMaxCookieSize is the default value of SafeCookie's MaxCookieSize,
+// matching the ~4 KiB per-cookie limit enforced by most browsers.
+const DefaultMaxCookieSize = 4096
+
+// chunkHeaderSize is the number of bytes used to encode the authenticated
+// chunk count prepended to the plaintext sealed by SealChunked.
+const chunkHeaderSize = 2
+
+// SealChunked seals data exactly as Seal does, then splits the resulting
+// Base64 ciphertext across as many cookies as are needed to keep each one,
+// as rendered by (*http.Cookie).String(), no longer than MaxCookieSize. The
+// cookies are named "<template.Name>.0", "<template.Name>.1", and so on; all
+// other fields are copied from template. The chunk count is authenticated as
+// part of the sealed plaintext and checked by OpenChunked once the
+// reassembled value has been opened; a dropped, reordered, or spliced chunk
+// also makes the reassembled value fail to open.
+func (sc *SafeCookie) SealChunked(data []byte, template *http.Cookie) ([]*http.Cookie, error) {
+	maxSize := sc.MaxCookieSize
+	if maxSize <= 0 {
+		maxSize = DefaultMaxCookieSize
+	}
+
+	name := template.Name
+
+	plainLen := timestampSize + chunkHeaderSize + len(data)
+	cipherLen := sc.AEAD.NonceSize() + plainLen + sc.AEAD.Overhead()
+	encodedLen := base64.URLEncoding.EncodedLen(cipherLen)
+
+	// The capacity available for a chunk's Value depends on the rendered
+	// length of its name, "<name>.<i>", which grows with the number of
+	// digits in i. Since a larger capacity can only ever produce a smaller
+	// or equal count, and a larger count can only ever need the same or
+	// more digits, recomputing capacity against the widest index actually
+	// used (count-1) converges in a handful of iterations.
+	var capacity int
+
+	count := 1
+	for {
+		capacity = maxSize - len(chunkCookie(template, name, count-1).String())
+		if capacity <= 0 {
+			return nil, usageError("MaxCookieSize too small for the given template", nil)
+		}
+
+		next := (encodedLen + capacity - 1) / capacity
+		if next < 1 {
+			next = 1
+		}
+
+		if next == count {
+			break
+		}
+
+		count = next
+	}
+
+	header := make([]byte, chunkHeaderSize)
+	binary.BigEndian.PutUint16(header, uint16(count))
+
+	sealed := &http.Cookie{Name: name}
+	if err := sc.Seal(append(header, data...), sealed); err != nil {
+		return nil, err
+	}
+
+	value := sealed.Value
+	cookies := make([]*http.Cookie, count)
+
+	for i := 0; i < count; i++ {
+		start := i * capacity
+		end := start + capacity
+
+		if end > len(value) {
+			end = len(value)
+		}
+
+		c := chunkCookie(template, name, i)
+		c.Value = value[start:end]
+		cookies[i] = c
+	}
+
+	return cookies, nil
+}
+
+// OpenChunked reassembles the cookies named "<name>.0", "<name>.1", and so
+// on (in any order, ignoring cookies whose names don't start with "<name>.")
+// and opens the result exactly as Open does. It returns ErrMalformedCookie if
+// no chunk is found, if a name starting with "<name>." doesn't end in an
+// integer index, if the indices found have a gap, or if the authenticated
+// chunk count doesn't match the number of chunks found. A value missing its
+// trailing chunks has no gap and returns whatever error Open returns for it.
+func (sc *SafeCookie) OpenChunked(cookies []*http.Cookie, name string) ([]byte, error) {
+	prefix := name + "."
+	chunks := make(map[int]string)
+
+	for _, c := range cookies {
+		if !strings.HasPrefix(c.Name, prefix) {
+			continue
+		}
+
+		i, err := strconv.Atoi(c.Name[len(prefix):])
+		if err != nil {
+			return nil, ErrMalformedCookie
+		}
+
+		chunks[i] = c.Value
+	}
+
+	if len(chunks) == 0 {
+		return nil, ErrMalformedCookie
+	}
+
+	var value strings.Builder
+
+	for i := 0; i < len(chunks); i++ {
+		v, ok := chunks[i]
+		if !ok {
+			return nil, ErrMalformedCookie
+		}
+
+		value.WriteString(v)
+	}
+
+	data, err := sc.Open(&http.Cookie{Name: name, Value: value.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < chunkHeaderSize {
+		return nil, ErrMalformedCookie
+	}
+
+	if count := binary.BigEndian.Uint16(data[:chunkHeaderSize]); int(count) != len(chunks) {
+		return nil, ErrMalformedCookie
+	}
+
+	return data[chunkHeaderSize:], nil
+}
+
+// chunkCookie returns a copy of template named "<name>.<i>" with an empty
+// value, suitable for sizing or as an output chunk.
+func chunkCookie(template *http.Cookie, name string, i int) *http.Cookie {
+	c := *template
+	c.Name = fmt.Sprintf("%s.%d", name, i)
+	c.Value = ""
+
+	return &c
+}
@@ -0,0 +1,122 @@
+package safecookie_test
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/codahale/safecookie"
+)
+
+func TestChunkedRoundTrip(t *testing.T) {
+	sc, err := safecookie.NewGCM([]byte("yellow submarine"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sc.MaxCookieSize = 64
+
+	original := bytes.Repeat([]byte("this is a lot of secret data "), 10)
+
+	cookies, err := sc.SealChunked(original, &http.Cookie{Name: "session"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(cookies) < 2 {
+		t.Fatalf("expected more than one chunk, got %d", len(cookies))
+	}
+
+	actual, err := sc.OpenChunked(cookies, "session")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(actual, original) {
+		t.Errorf("Was %x, but expected %x", actual, original)
+	}
+}
+
+func TestChunkedUnordered(t *testing.T) {
+	sc, err := safecookie.NewGCM([]byte("yellow submarine"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sc.MaxCookieSize = 64
+
+	original := bytes.Repeat([]byte("this is a lot of secret data "), 10)
+
+	cookies, err := sc.SealChunked(original, &http.Cookie{Name: "session"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Shuffle the cookies' relative order; OpenChunked reassembles by name.
+	reversed := make([]*http.Cookie, len(cookies))
+	for i, c := range cookies {
+		reversed[len(cookies)-1-i] = c
+	}
+
+	actual, err := sc.OpenChunked(reversed, "session")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(actual, original) {
+		t.Errorf("Was %x, but expected %x", actual, original)
+	}
+}
+
+func TestChunkedMissingChunk(t *testing.T) {
+	sc, err := safecookie.NewGCM([]byte("yellow submarine"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sc.MaxCookieSize = 64
+
+	original := bytes.Repeat([]byte("this is a lot of secret data "), 10)
+
+	cookies, err := sc.SealChunked(original, &http.Cookie{Name: "session"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(cookies) < 3 {
+		t.Fatalf("expected at least three chunks, got %d", len(cookies))
+	}
+
+	missing := append(cookies[:1], cookies[2:]...)
+
+	if _, err := sc.OpenChunked(missing, "session"); err != safecookie.ErrMalformedCookie {
+		t.Errorf("Was %v, but expected ErrMalformedCookie", err)
+	}
+}
+
+func TestChunkedSplicedChunk(t *testing.T) {
+	sc, err := safecookie.NewGCM([]byte("yellow submarine"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sc.MaxCookieSize = 64
+
+	first, err := sc.SealChunked(bytes.Repeat([]byte("a"), 200), &http.Cookie{Name: "session"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := sc.SealChunked(bytes.Repeat([]byte("b"), 200), &http.Cookie{Name: "session"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Splice a chunk from a different sealed value into the first set.
+	spliced := append([]*http.Cookie{}, first...)
+	spliced[0] = second[0]
+
+	if _, err := sc.OpenChunked(spliced, "session"); err == nil {
+		t.Error("expected spliced chunks to fail to authenticate")
+	}
+}
@@ -0,0 +1,118 @@
+package safecookie
+
+// Error is the interface implemented by the errors returned by this package,
+// except errors from a Serializer, which SealValue and OpenValue return
+// unchanged. It lets callers classify failures without string matching: usage errors
+// indicate a programming mistake (e.g. a bad key or an oversized
+// plaintext), decode errors indicate an untrusted cookie that failed to
+// parse, authenticate, or meet its age constraints, and internal errors
+// indicate a failure of the runtime (e.g. the CSPRNG).
+type Error interface {
+	error
+
+	// IsUsage returns true if the error was caused by the caller misusing
+	// the API, e.g. providing an invalid key or an oversized plaintext.
+	IsUsage() bool
+
+	// IsDecode returns true if the error was caused by an invalid,
+	// corrupted, forged, or expired cookie.
+	IsDecode() bool
+
+	// IsInternal returns true if the error was caused by a failure of the
+	// underlying runtime, e.g. the CSPRNG being unavailable.
+	IsInternal() bool
+
+	// Unwrap returns the underlying cause of the error, if any.
+	Unwrap() error
+}
+
+// errKind classifies a cookieError for IsUsage/IsDecode/IsInternal.
+type errKind int
+
+const (
+	kindUsage errKind = iota
+	kindDecode
+	kindInternal
+)
+
+// cookieError is the concrete type behind every error this package returns.
+type cookieError struct {
+	kind  errKind
+	msg   string
+	cause error
+}
+
+func (e *cookieError) Error() string {
+	if e.cause != nil {
+		return e.msg + ": " + e.cause.Error()
+	}
+
+	return e.msg
+}
+
+func (e *cookieError) IsUsage() bool    { return e.kind == kindUsage }
+func (e *cookieError) IsDecode() bool   { return e.kind == kindDecode }
+func (e *cookieError) IsInternal() bool { return e.kind == kindInternal }
+func (e *cookieError) Unwrap() error    { return e.cause }
+
+// Is reports whether this error should be considered equal to target by
+// errors.Is. ErrInvalidCookie matches any decode-class error, and any two
+// cookieErrors of the same kind and message (e.g. a wrapped
+// ErrMalformedCookie and the sentinel itself) match each other, preserving
+// compatibility with code written against the single-sentinel API.
+func (e *cookieError) Is(target error) bool {
+	if target == ErrInvalidCookie {
+		return e.kind == kindDecode
+	}
+
+	if t, ok := target.(*cookieError); ok {
+		return e.kind == t.kind && e.msg == t.msg
+	}
+
+	return false
+}
+
+func usageError(msg string, cause error) *cookieError {
+	return &cookieError{kind: kindUsage, msg: msg, cause: cause}
+}
+
+func decodeError(msg string, cause error) *cookieError {
+	return &cookieError{kind: kindDecode, msg: msg, cause: cause}
+}
+
+func internalError(msg string, cause error) *cookieError {
+	return &cookieError{kind: kindInternal, msg: msg, cause: cause}
+}
+
+var (
+	// ErrInvalidCookie is returned if the cookie is invalid. It also
+	// matches, via errors.Is, any other decode-class error this package
+	// returns (e.g. ErrMalformedCookie or ErrExpiredCookie), so existing
+	// code that only checks for ErrInvalidCookie keeps working.
+	ErrInvalidCookie Error = decodeError("invalid cookie", nil)
+
+	// ErrMalformedCookie is returned if the cookie's value isn't validly
+	// Base64-encoded, or decodes to no more bytes than a nonce.
+	ErrMalformedCookie Error = decodeError("malformed cookie", nil)
+
+	// ErrAuthenticationFailed is returned if the cookie's value fails to
+	// authenticate, e.g. because its name or value was changed, or it was
+	// sealed with a different key.
+	ErrAuthenticationFailed Error = decodeError("authentication failed", nil)
+
+	// ErrExpiredCookie is returned if the cookie is valid but older than
+	// its SafeCookie's MaxAge.
+	ErrExpiredCookie Error = decodeError("expired cookie", nil)
+
+	// ErrCookieNotYetValid is returned if the cookie is valid but younger
+	// than its SafeCookie's MinAge.
+	ErrCookieNotYetValid Error = decodeError("cookie not yet valid", nil)
+
+	// ErrOversizedPlaintext is returned by Seal if the given plaintext
+	// exceeds MaxPlaintextSize.
+	ErrOversizedPlaintext Error = usageError("oversized plaintext", nil)
+
+	// ErrRandomFailure is returned by Seal if the system's CSPRNG fails to
+	// produce a nonce.
+	ErrRandomFailure Error = internalError("failed to read random bytes", nil)
+)
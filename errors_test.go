@@ -0,0 +1,67 @@
+package safecookie_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/codahale/safecookie"
+)
+
+func TestErrorClassification(t *testing.T) {
+	c := http.Cookie{
+		Name: "wingle",
+	}
+
+	sc, err := safecookie.NewGCM([]byte("yellow submarine"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := sc.Seal([]byte("this is a secret"), &c); err != nil {
+		t.Fatal(err)
+	}
+
+	c.Value += "**@3"
+
+	_, err = sc.Open(&c)
+
+	var cerr safecookie.Error
+	if !errors.As(err, &cerr) {
+		t.Fatalf("Was %#v, but expected a safecookie.Error", err)
+	}
+
+	if !cerr.IsDecode() || cerr.IsUsage() || cerr.IsInternal() {
+		t.Errorf("Was %#v, but expected a decode-class error", cerr)
+	}
+
+	if !errors.Is(err, safecookie.ErrInvalidCookie) {
+		t.Errorf("Was %v, but expected to match ErrInvalidCookie", err)
+	}
+
+	if !errors.Is(err, safecookie.ErrMalformedCookie) {
+		t.Errorf("Was %v, but expected to match ErrMalformedCookie", err)
+	}
+}
+
+func TestErrorOversizedPlaintext(t *testing.T) {
+	c := http.Cookie{
+		Name: "wingle",
+	}
+
+	sc, err := safecookie.NewGCM([]byte("yellow submarine"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = sc.Seal(make([]byte, safecookie.MaxPlaintextSize+1), &c)
+
+	var cerr safecookie.Error
+	if !errors.As(err, &cerr) || !cerr.IsUsage() {
+		t.Errorf("Was %#v, but expected a usage-class error", err)
+	}
+
+	if !errors.Is(err, safecookie.ErrOversizedPlaintext) {
+		t.Errorf("Was %v, but expected to match ErrOversizedPlaintext", err)
+	}
+}
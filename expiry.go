@@ -0,0 +1,23 @@
+package safecookie
+
+import (
+	"encoding/binary"
+	"time"
+)
+
+// timestampSize is the number of bytes used to encode the authenticated
+// unix-seconds timestamp prepended to every sealed value.
+const timestampSize = 8
+
+// putTimestamp returns t encoded as a big-endian unix-seconds timestamp.
+func putTimestamp(t time.Time) []byte {
+	b := make([]byte, timestampSize)
+	binary.BigEndian.PutUint64(b, uint64(t.Unix()))
+
+	return b
+}
+
+// parseTimestamp decodes a big-endian unix-seconds timestamp.
+func parseTimestamp(b []byte) time.Time {
+	return time.Unix(int64(binary.BigEndian.Uint64(b)), 0)
+}
@@ -0,0 +1,116 @@
+package safecookie_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/codahale/safecookie"
+)
+
+func TestMaxAgeExpiredCookie(t *testing.T) {
+	c := http.Cookie{
+		Name: "wingle",
+	}
+
+	sc, err := safecookie.NewGCM([]byte("yellow submarine"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sc.MaxAge = 50 * time.Millisecond
+
+	if err := sc.Seal([]byte("this is a secret"), &c); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	if _, err := sc.Open(&c); err != safecookie.ErrExpiredCookie {
+		t.Errorf("Was %v, but expected ErrExpiredCookie", err)
+	}
+}
+
+func TestMaxAgeFreshCookie(t *testing.T) {
+	c := http.Cookie{
+		Name: "wingle",
+	}
+
+	sc, err := safecookie.NewGCM([]byte("yellow submarine"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sc.MaxAge = time.Hour
+
+	if err := sc.Seal([]byte("this is a secret"), &c); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := sc.Open(&c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMinAgeNotYetValid(t *testing.T) {
+	c := http.Cookie{
+		Name: "wingle",
+	}
+
+	sc, err := safecookie.NewGCM([]byte("yellow submarine"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sc.MinAge = time.Hour
+
+	if err := sc.Seal([]byte("this is a secret"), &c); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := sc.Open(&c); err != safecookie.ErrCookieNotYetValid {
+		t.Errorf("Was %v, but expected ErrCookieNotYetValid", err)
+	}
+}
+
+func TestMinAgeValid(t *testing.T) {
+	c := http.Cookie{
+		Name: "wingle",
+	}
+
+	sc, err := safecookie.NewGCM([]byte("yellow submarine"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sc.MinAge = 50 * time.Millisecond
+
+	if err := sc.Seal([]byte("this is a secret"), &c); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	if _, err := sc.Open(&c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNoMaxAgeBackwardCompatible(t *testing.T) {
+	c := http.Cookie{
+		Name: "wingle",
+	}
+
+	sc, err := safecookie.NewGCM([]byte("yellow submarine"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := sc.Seal([]byte("this is a secret"), &c); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := sc.Open(&c); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,73 @@
+package safecookie
+
+import (
+	"crypto/cipher"
+	"crypto/hmac"
+	"encoding/binary"
+	"hash"
+)
+
+// NewHMAC returns a new SafeCookie instance that signs but does not encrypt
+// cookie values, using an HMAC over the given hash constructor and key. This
+// is useful for values that must remain legible to the client, such as
+// feature flags or non-sensitive user IDs, while still being tamper-evident:
+// any change to the cookie's name or value invalidates it, exactly as with
+// an encrypting SafeCookie.
+func NewHMAC(key []byte, h func() hash.Hash) (*SafeCookie, error) {
+	if len(key) == 0 {
+		return nil, usageError("hmac: key must not be empty", nil)
+	}
+
+	return &SafeCookie{AEAD: &hmacAEAD{key: key, h: h}}, nil
+}
+
+// hmacAEAD adapts an HMAC to the cipher.AEAD interface: Seal authenticates
+// the big-endian 64-bit length of additionalData, then additionalData, then
+// the plaintext, and appends the tag to the (unencrypted) plaintext; Open
+// splits the tag back off and verifies it.
+type hmacAEAD struct {
+	key []byte
+	h   func() hash.Hash
+}
+
+func (a *hmacAEAD) NonceSize() int { return 0 }
+
+func (a *hmacAEAD) Overhead() int { return hmac.New(a.h, a.key).Size() }
+
+func (a *hmacAEAD) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
+	tag := a.tag(additionalData, plaintext)
+
+	ret := append(dst, plaintext...)
+
+	return append(ret, tag...)
+}
+
+func (a *hmacAEAD) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	tagSize := a.Overhead()
+	if len(ciphertext) < tagSize {
+		return nil, decodeError("hmac: authentication failed", nil)
+	}
+
+	plaintext := ciphertext[:len(ciphertext)-tagSize]
+	tag := ciphertext[len(ciphertext)-tagSize:]
+
+	if !hmac.Equal(tag, a.tag(additionalData, plaintext)) {
+		return nil, decodeError("hmac: authentication failed", nil)
+	}
+
+	return append(dst, plaintext...), nil
+}
+
+func (a *hmacAEAD) tag(additionalData, plaintext []byte) []byte {
+	var n [8]byte
+	binary.BigEndian.PutUint64(n[:], uint64(len(additionalData)))
+
+	mac := hmac.New(a.h, a.key)
+	mac.Write(n[:])
+	mac.Write(additionalData)
+	mac.Write(plaintext)
+
+	return mac.Sum(nil)
+}
+
+var _ cipher.AEAD = (*hmacAEAD)(nil)
@@ -0,0 +1,69 @@
+package safecookie_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	"github.com/codahale/safecookie"
+)
+
+func TestHMACRoundTrip(t *testing.T) {
+	original := []byte("feature=new-checkout")
+
+	c := http.Cookie{
+		Name: "wingle",
+	}
+
+	sc, err := safecookie.NewHMAC([]byte("yellow submarine"), sha256.New)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := sc.Seal(original, &c); err != nil {
+		t.Fatal(err)
+	}
+
+	// The value is signed, not encrypted, so it decodes straight to the
+	// (timestamp-prefixed) plaintext rather than ciphertext.
+	decoded, err := base64.URLEncoding.DecodeString(c.Value)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Contains(decoded, original) {
+		t.Errorf("expected %q to remain legible in decoded value %q", original, decoded)
+	}
+
+	actual, err := sc.Open(&c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(actual, original) {
+		t.Errorf("Was %x, but expected %x", actual, original)
+	}
+}
+
+func TestHMACBadValue(t *testing.T) {
+	c := http.Cookie{
+		Name: "wingle",
+	}
+
+	sc, err := safecookie.NewHMAC([]byte("yellow submarine"), sha256.New)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := sc.Seal([]byte("feature=new-checkout"), &c); err != nil {
+		t.Fatal(err)
+	}
+
+	c.Value = c.Value[:len(c.Value)-2] + "AA"
+
+	if data, err := sc.Open(&c); err == nil {
+		t.Errorf("Was %#v, but expected an error", data)
+	}
+}
@@ -0,0 +1,60 @@
+package safecookie
+
+import "net/http"
+
+// KeySet seals cookies with the first (current) SafeCookie instance and
+// opens them by trying each instance in order, returning the plaintext from
+// the first one that authenticates. This allows operators to rotate the
+// AEAD key without invalidating sessions sealed under an older key: add the
+// new key to the front of the set, keep the old ones around until they
+// decommission, and drop them once enough time has passed that no
+// outstanding cookie could still use them.
+type KeySet struct {
+	// Keys is the ordered list of SafeCookie instances to use, from most to
+	// least current. Seal always uses Keys[0]; Open tries each in order.
+	Keys []*SafeCookie
+}
+
+// NewGCMSet returns a new KeySet of AES-GCM-based SafeCookie instances given
+// one or more 128-, 192-, or 256-bit keys, in order from most to least
+// current.
+func NewGCMSet(keys ...[]byte) (*KeySet, error) {
+	if len(keys) == 0 {
+		return nil, usageError("no keys given", nil)
+	}
+
+	scs := make([]*SafeCookie, len(keys))
+
+	for i, key := range keys {
+		sc, err := NewGCM(key)
+		if err != nil {
+			return nil, err
+		}
+
+		scs[i] = sc
+	}
+
+	return &KeySet{Keys: scs}, nil
+}
+
+// Seal encrypts the data using the cookie's name as authenticated data, and
+// sets the cookie's value to the Base64-encoded ciphertext, using the
+// current (first) key in the set.
+func (ks *KeySet) Seal(data []byte, c *http.Cookie) error {
+	return ks.Keys[0].Seal(data, c)
+}
+
+// Open decodes the cookie's value as Base64 and decrypts it, trying each key
+// in the set in order and returning the plaintext from the first one that
+// authenticates. If no key authenticates the cookie, it returns
+// ErrInvalidCookie.
+func (ks *KeySet) Open(c *http.Cookie) ([]byte, error) {
+	for _, sc := range ks.Keys {
+		data, err := sc.Open(c)
+		if err == nil {
+			return data, nil
+		}
+	}
+
+	return nil, ErrInvalidCookie
+}
@@ -0,0 +1,120 @@
+package safecookie_test
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/codahale/safecookie"
+)
+
+func TestKeySetRoundTrip(t *testing.T) {
+	original := []byte("this is a secret")
+
+	c := http.Cookie{
+		Name: "wingle",
+	}
+
+	ks, err := safecookie.NewGCMSet([]byte("yellow submarine"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ks.Seal(original, &c); err != nil {
+		t.Fatal(err)
+	}
+
+	actual, err := ks.Open(&c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(actual, original) {
+		t.Errorf("Was %x, but expected %x", actual, original)
+	}
+}
+
+func TestKeySetRotation(t *testing.T) {
+	original := []byte("this is a secret")
+
+	c := http.Cookie{
+		Name: "wingle",
+	}
+
+	// Seal with the old key only.
+	oldSet, err := safecookie.NewGCMSet([]byte("old key, old key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := oldSet.Seal(original, &c); err != nil {
+		t.Fatal(err)
+	}
+
+	// Open with a set containing the new key first, then the old key.
+	newSet, err := safecookie.NewGCMSet([]byte("new key, new key"), []byte("old key, old key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actual, err := newSet.Open(&c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(actual, original) {
+		t.Errorf("Was %x, but expected %x", actual, original)
+	}
+}
+
+func TestKeySetDecommissioned(t *testing.T) {
+	original := []byte("this is a secret")
+
+	c := http.Cookie{
+		Name: "wingle",
+	}
+
+	oldSet, err := safecookie.NewGCMSet([]byte("old key, old key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := oldSet.Seal(original, &c); err != nil {
+		t.Fatal(err)
+	}
+
+	// The old key has been decommissioned and is no longer in the set.
+	newSet, err := safecookie.NewGCMSet([]byte("new key, new key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := newSet.Open(&c); err != safecookie.ErrInvalidCookie {
+		t.Errorf("Was %v, but expected ErrInvalidCookie", err)
+	}
+}
+
+func TestKeySetMixedKeys(t *testing.T) {
+	c := http.Cookie{
+		Name: "wingle",
+	}
+
+	ks, err := safecookie.NewGCMSet([]byte("key one, key one"), []byte("key two, key two"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Seal with the second key directly, bypassing the current key.
+	if err := ks.Keys[1].Seal([]byte("mixed"), &c); err != nil {
+		t.Fatal(err)
+	}
+
+	actual, err := ks.Open(&c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(actual, []byte("mixed")) {
+		t.Errorf("Was %x, but expected %q", actual, "mixed")
+	}
+}
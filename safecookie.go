@@ -21,20 +21,42 @@ import (
 	"crypto/cipher"
 	"crypto/rand"
 	"encoding/base64"
-	"errors"
 	"net/http"
+	"time"
 )
 
-var (
-	// ErrInvalidCookie is returned if the cookie is invalid.
-	ErrInvalidCookie = errors.New("invalid cookie")
-)
+// MaxPlaintextSize is the maximum size, in bytes, of a plaintext
+// passed to Seal. It exists as a sanity bound, not a protocol limit.
+const MaxPlaintextSize = 1 << 20 // 1 MiB
 
 // SafeCookie seals cookies and opens them.
 type SafeCookie struct {
 	// AEAD is the Authenticated Encryption And Data algorithm to use for
 	// encrypting and decrypting cookie values.
 	AEAD cipher.AEAD
+
+	// Serializer is used by SealValue and OpenValue to encode and decode
+	// typed values. If nil, JSONSerializer is used.
+	Serializer Serializer
+
+	// MaxAge, if non-zero, is the maximum amount of time a sealed cookie is
+	// considered valid for. Open returns ErrExpiredCookie for cookies older
+	// than MaxAge. The cookie's timestamp is authenticated as part of the
+	// sealed value, so a client cannot forge or extend it by editing
+	// Cookie.Expires. The timestamp is truncated to whole seconds, so the age
+	// Open measures can exceed a cookie's true age by up to a second.
+	MaxAge time.Duration
+
+	// MinAge, if non-zero, is the minimum amount of time that must have
+	// passed since a cookie was sealed for it to be considered valid. Open
+	// returns ErrCookieNotYetValid for cookies younger than MinAge, measuring
+	// age from the same whole-second timestamp as MaxAge.
+	MinAge time.Duration
+
+	// MaxCookieSize is the maximum size, in bytes, of a single cookie
+	// produced by SealChunked, as rendered by (*http.Cookie).String(). If
+	// zero, DefaultMaxCookieSize is used.
+	MaxCookieSize int
 }
 
 // NewGCM returns a new AES-GCM-based SafeCookie instance given a 128-, 192-, or
@@ -42,47 +64,99 @@ type SafeCookie struct {
 func NewGCM(key []byte) (*SafeCookie, error) {
 	b, err := aes.NewCipher(key)
 	if err != nil {
-		return nil, err
+		return nil, usageError("invalid key", err)
 	}
 
 	gcm, err := cipher.NewGCM(b)
 	if err != nil {
-		return nil, err
+		return nil, usageError("invalid key", err)
 	}
 
 	return &SafeCookie{AEAD: gcm}, nil
 }
 
-// Seal encrypts the data using the cookie's name as authenticated data, and
-// sets the cookie's value to the Base64-encoded ciphertext.
+// Seal encrypts the data, prefixed with an authenticated timestamp, using
+// the cookie's name as authenticated data, and sets the cookie's value to
+// the Base64-encoded ciphertext.
 func (sc *SafeCookie) Seal(data []byte, c *http.Cookie) error {
+	if len(data) > MaxPlaintextSize {
+		return ErrOversizedPlaintext
+	}
+
 	nonce := make([]byte, sc.AEAD.NonceSize())
 	if _, err := rand.Read(nonce); err != nil {
-		return err
+		return internalError("failed to read random bytes", err)
 	}
 
-	ciphertext := sc.AEAD.Seal(nonce, nonce, data, []byte(c.Name))
+	plaintext := append(putTimestamp(time.Now()), data...)
+	ciphertext := sc.AEAD.Seal(nonce, nonce, plaintext, []byte(c.Name))
 	c.Value = base64.URLEncoding.EncodeToString(ciphertext)
 
 	return nil
 }
 
 // Open decodes the cookie's value as Base64, decrypts it (authenticating the
-// cookie name), and returns the decrypted value. If the cookie is invalid, it
-// returns ErrInvalidCookie.
+// cookie name), and returns the decrypted value. If the cookie's value isn't
+// validly encoded, it returns ErrMalformedCookie; if it fails to
+// authenticate, it returns ErrAuthenticationFailed. Both satisfy
+// errors.Is(err, ErrInvalidCookie). If the cookie is older than MaxAge, it
+// returns ErrExpiredCookie; if it is younger than MinAge, it returns
+// ErrCookieNotYetValid.
 func (sc *SafeCookie) Open(c *http.Cookie) ([]byte, error) {
 	b, err := base64.URLEncoding.DecodeString(c.Value)
 	if err != nil || len(b) <= sc.AEAD.NonceSize() {
-		return nil, ErrInvalidCookie
+		return nil, ErrMalformedCookie
 	}
 
 	nonce := b[:sc.AEAD.NonceSize()]
 	ciphertext := b[sc.AEAD.NonceSize():]
 
 	b, err = sc.AEAD.Open(nil, nonce, ciphertext, []byte(c.Name))
+	if err != nil || len(b) < timestampSize {
+		return nil, ErrAuthenticationFailed
+	}
+
+	sealedAt := parseTimestamp(b[:timestampSize])
+	age := time.Since(sealedAt)
+
+	if sc.MaxAge != 0 && age > sc.MaxAge {
+		return nil, ErrExpiredCookie
+	}
+
+	if sc.MinAge != 0 && age < sc.MinAge {
+		return nil, ErrCookieNotYetValid
+	}
+
+	return b[timestampSize:], nil
+}
+
+// SealValue serializes v using the Serializer and seals the result with
+// Seal, setting the cookie's value to the Base64-encoded ciphertext.
+func (sc *SafeCookie) SealValue(v interface{}, c *http.Cookie) error {
+	data, err := sc.serializer().Serialize(v)
+	if err != nil {
+		return err
+	}
+
+	return sc.Seal(data, c)
+}
+
+// OpenValue opens the cookie with Open and deserializes the result into v
+// using the Serializer.
+func (sc *SafeCookie) OpenValue(c *http.Cookie, v interface{}) error {
+	data, err := sc.Open(c)
 	if err != nil {
-		return nil, ErrInvalidCookie
+		return err
+	}
+
+	return sc.serializer().Deserialize(data, v)
+}
+
+// serializer returns sc.Serializer, or JSONSerializer if it is nil.
+func (sc *SafeCookie) serializer() Serializer {
+	if sc.Serializer == nil {
+		return JSONSerializer{}
 	}
 
-	return b, nil
+	return sc.Serializer
 }
@@ -2,6 +2,7 @@ package safecookie_test
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -102,7 +103,7 @@ func TestBadName(t *testing.T) {
 
 	c.Name = "wongle"
 
-	if data, err := sc.Open(&c); err != safecookie.ErrInvalidCookie {
+	if data, err := sc.Open(&c); !errors.Is(err, safecookie.ErrInvalidCookie) {
 		t.Errorf("Was %#v, but expected ErrInvalidCookie", data)
 	}
 }
@@ -125,7 +126,7 @@ func TestBadValue(t *testing.T) {
 
 	c.Value = "rQ" + c.Value[2:]
 
-	if data, err := sc.Open(&c); err != safecookie.ErrInvalidCookie {
+	if data, err := sc.Open(&c); !errors.Is(err, safecookie.ErrInvalidCookie) {
 		t.Errorf("Was %#v, but expected ErrInvalidCookie", data)
 	}
 }
@@ -148,7 +149,7 @@ func TestBadEncoding(t *testing.T) {
 
 	c.Value += "**@3"
 
-	if data, err := sc.Open(&c); err != safecookie.ErrInvalidCookie {
+	if data, err := sc.Open(&c); !errors.Is(err, safecookie.ErrInvalidCookie) {
 		t.Errorf("Was %#v, but expected ErrInvalidCookie", data)
 	}
 }
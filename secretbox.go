@@ -0,0 +1,68 @@
+package safecookie
+
+import (
+	"crypto/cipher"
+	"crypto/sha256"
+	"crypto/subtle"
+
+	"golang.org/x/crypto/nacl/secretbox"
+)
+
+// NewSecretbox returns a new SafeCookie instance backed by NaCl's secretbox
+// (XSalsa20-Poly1305) given a 32-byte key.
+func NewSecretbox(key []byte) (*SafeCookie, error) {
+	if len(key) != 32 {
+		return nil, usageError("secretbox: key must be 32 bytes", nil)
+	}
+
+	var k [32]byte
+	copy(k[:], key)
+
+	return &SafeCookie{AEAD: &secretboxAEAD{key: k}}, nil
+}
+
+// secretboxAEAD adapts NaCl's secretbox, which has no notion of additional
+// authenticated data, to the cipher.AEAD interface by binding a fixed-size
+// digest of the additional data onto the front of the sealed message and
+// verifying it after opening. Using a digest, rather than the additional
+// data itself, keeps Overhead() a fixed value regardless of the additional
+// data's length, as callers such as SealChunked rely on it to size chunks.
+type secretboxAEAD struct {
+	key [32]byte
+}
+
+func (s *secretboxAEAD) NonceSize() int { return 24 }
+
+func (s *secretboxAEAD) Overhead() int { return secretbox.Overhead + sha256.Size }
+
+func (s *secretboxAEAD) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
+	var n [24]byte
+	copy(n[:], nonce)
+
+	digest := sha256.Sum256(additionalData)
+	message := append(digest[:], plaintext...)
+
+	return secretbox.Seal(dst, message, &n, &s.key)
+}
+
+func (s *secretboxAEAD) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	var n [24]byte
+	copy(n[:], nonce)
+
+	message, ok := secretbox.Open(nil, ciphertext, &n, &s.key)
+	if !ok || len(message) < sha256.Size {
+		return nil, decodeError("secretbox: authentication failed", nil)
+	}
+
+	digest := message[:sha256.Size]
+	plaintext := message[sha256.Size:]
+
+	expected := sha256.Sum256(additionalData)
+	if subtle.ConstantTimeCompare(digest, expected[:]) != 1 {
+		return nil, decodeError("secretbox: authentication failed", nil)
+	}
+
+	return append(dst, plaintext...), nil
+}
+
+var _ cipher.AEAD = (*secretboxAEAD)(nil)
@@ -0,0 +1,56 @@
+package safecookie_test
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/codahale/safecookie"
+)
+
+func TestSecretboxRoundTrip(t *testing.T) {
+	original := []byte("this is a secret")
+
+	c := http.Cookie{
+		Name: "wingle",
+	}
+
+	sc, err := safecookie.NewSecretbox([]byte("yellow submarine, yellow subma!!"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := sc.Seal(original, &c); err != nil {
+		t.Fatal(err)
+	}
+
+	actual, err := sc.Open(&c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(actual, original) {
+		t.Errorf("Was %x, but expected %x", actual, original)
+	}
+}
+
+func TestSecretboxBadName(t *testing.T) {
+	c := http.Cookie{
+		Name: "wingle",
+	}
+
+	sc, err := safecookie.NewSecretbox([]byte("yellow submarine, yellow subma!!"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := sc.Seal([]byte("this is a secret"), &c); err != nil {
+		t.Fatal(err)
+	}
+
+	c.Name = "wongle"
+
+	if data, err := sc.Open(&c); err == nil {
+		t.Errorf("Was %#v, but expected an error", data)
+	}
+}
@@ -0,0 +1,48 @@
+package safecookie
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+)
+
+// Serializer encodes and decodes values to and from bytes for use with
+// SealValue and OpenValue.
+type Serializer interface {
+	// Serialize encodes v as a byte slice.
+	Serialize(v interface{}) ([]byte, error)
+
+	// Deserialize decodes b into v.
+	Deserialize(b []byte, v interface{}) error
+}
+
+// JSONSerializer serializes values as JSON.
+type JSONSerializer struct{}
+
+// Serialize encodes v as JSON.
+func (JSONSerializer) Serialize(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Deserialize decodes JSON-encoded b into v.
+func (JSONSerializer) Deserialize(b []byte, v interface{}) error {
+	return json.Unmarshal(b, v)
+}
+
+// GobSerializer serializes values using encoding/gob.
+type GobSerializer struct{}
+
+// Serialize encodes v using gob.
+func (GobSerializer) Serialize(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Deserialize decodes gob-encoded b into v.
+func (GobSerializer) Deserialize(b []byte, v interface{}) error {
+	return gob.NewDecoder(bytes.NewReader(b)).Decode(v)
+}
@@ -0,0 +1,67 @@
+package safecookie_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/codahale/safecookie"
+)
+
+type session struct {
+	UserID int
+	Admin  bool
+}
+
+func TestSealValueOpenValueJSON(t *testing.T) {
+	c := http.Cookie{
+		Name: "wingle",
+	}
+
+	sc, err := safecookie.NewGCM([]byte("yellow submarine"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	original := session{UserID: 42, Admin: true}
+
+	if err := sc.SealValue(original, &c); err != nil {
+		t.Fatal(err)
+	}
+
+	var actual session
+	if err := sc.OpenValue(&c, &actual); err != nil {
+		t.Fatal(err)
+	}
+
+	if actual != original {
+		t.Errorf("Was %+v, but expected %+v", actual, original)
+	}
+}
+
+func TestSealValueOpenValueGob(t *testing.T) {
+	c := http.Cookie{
+		Name: "wingle",
+	}
+
+	sc, err := safecookie.NewGCM([]byte("yellow submarine"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sc.Serializer = safecookie.GobSerializer{}
+
+	original := session{UserID: 42, Admin: true}
+
+	if err := sc.SealValue(original, &c); err != nil {
+		t.Fatal(err)
+	}
+
+	var actual session
+	if err := sc.OpenValue(&c, &actual); err != nil {
+		t.Fatal(err)
+	}
+
+	if actual != original {
+		t.Errorf("Was %+v, but expected %+v", actual, original)
+	}
+}